@@ -0,0 +1,194 @@
+package psampl
+
+import (
+	"math"
+)
+
+// SampleCount returns the number of heads out of n independent flips of
+// bbs, without calling SampleBit n times: it uses the BTPE algorithm
+// (Kachitvichyanukul & Schmeiser's Binomial Triangle-Parallelogram-
+// Exponential rejection method) when n*min(p,1-p)>=10, and inversion via
+// the cumulative binomial probabilities otherwise, where BTPE's setup cost
+// isn't worth paying.
+func (bbs *BiasBitSource) SampleCount(n int) int {
+	p := bbs.prOne
+	if n <= 0 || p <= 0 {
+		return 0
+	}
+	if p >= 1 {
+		return n
+	}
+	if float64(n)*math.Min(p, 1-p) >= 10 {
+		return binomialBTPE(n, p, bbs.rsrc)
+	}
+	return binomialInversion(n, p, bbs.rsrc)
+}
+
+// binomialInversion draws a Binomial(n, p) sample by walking the
+// cumulative distribution function from k=0, using the standard recurrence
+// pmf(k) = pmf(k-1) * (a/k - s) with s=r/(1-r), a=(n+1)*s and r=min(p,1-p).
+func binomialInversion(n int, p float64, rsrc uniform) int {
+	r := p
+	flip := p > 0.5
+	if flip {
+		r = 1 - p
+	}
+	s := r / (1 - r)
+	a := float64(n+1) * s
+	u := rsrc.Float64()
+	f := math.Pow(1-r, float64(n))
+	k := 0
+	for u > f {
+		u -= f
+		k++
+		if k > n {
+			k = n
+			break
+		}
+		f *= a/float64(k) - s
+	}
+	if flip {
+		k = n - k
+	}
+	return k
+}
+
+// binomialBTPE draws a Binomial(n, p) sample using the BTPE algorithm: it
+// partitions the pmf into a central triangle region, two parallelogram
+// wings and two exponential tails, samples uniformly over that envelope,
+// and squeezes/rejects against the true pmf, giving O(1) expected time
+// independent of n.
+func binomialBTPE(n int, p float64, rsrc uniform) int {
+	r := math.Min(p, 1-p)
+	q := 1 - r
+	fn := float64(n)
+	fm := fn*r + r
+	m := math.Floor(fm)
+	p1 := math.Floor(2.195*math.Sqrt(fn*r*q)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*r)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+	nrq := fn * r * q
+
+	var y float64
+sample:
+	for {
+		u := rsrc.Float64() * p4
+		v := rsrc.Float64()
+		switch {
+		case u <= p1:
+			// the triangle lies entirely under the pmf: accept at once.
+			y = math.Floor(xm - p1*v + u)
+			break sample
+		case u <= p2:
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 || v <= 0 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 {
+				continue
+			}
+			v = v * (u - p2) * laml
+		default:
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > fn {
+				continue
+			}
+			v = v * (u - p3) * lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			s := r / q
+			a := s * (fn + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v > f {
+				continue
+			}
+			break
+		}
+
+		rho := (k / nrq) * ((k*(k/3+0.625)+0.1666666666666)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		logV := math.Log(v)
+		if logV < t-rho {
+			break
+		}
+		if logV > t+rho {
+			continue
+		}
+
+		x1 := y + 1
+		f1 := m + 1
+		z := fn + 1 - m
+		w := fn - y + 1
+		x2, f2, z2, w2 := x1*x1, f1*f1, z*z, w*w
+		bound := xm*math.Log(f1/x1) + (fn-m+0.5)*math.Log(z/w) + (y-m)*math.Log(w*r/(x1*q)) +
+			(13860-(462-(132-(99-140/f2)/f2)/f2)/f2)/f1/166320 +
+			(13860-(462-(132-(99-140/z2)/z2)/z2)/z2)/z/166320 +
+			(13860-(462-(132-(99-140/x2)/x2)/x2)/x2)/x1/166320 +
+			(13860-(462-(132-(99-140/w2)/w2)/w2)/w2)/w/166320
+		if logV <= bound {
+			break
+		}
+	}
+
+	if p > 0.5 {
+		y = fn - y
+	}
+	return int(y)
+}
+
+// SampleMulti fills out with a multinomial draw of n trials from bs's
+// Distrib: out[i] receives the count of trials that landed on value i, and
+// sum(out) == n. It uses the conditional-binomial decomposition (draw
+// X0~Bin(n,p0), then X1~Bin(n-X0,p1/(1-p0)), and so on) so that drawing a
+// histogram of K values costs O(K) binomial samples via SampleCount rather
+// than O(n) individual SampleInt calls.
+func (bs *BiasSource) SampleMulti(n int, out []int) {
+	probs := bs.d.origProb
+	if len(out) != len(probs) {
+		panic("out must have the same length as the Distrib's number of values")
+	}
+
+	remaining := n
+	remainingP := 1.0
+	bbs := &BiasBitSource{rsrc: bs.rsrc}
+	for i := 0; i < len(probs)-1; i++ {
+		if remaining <= 0 || remainingP <= 0 {
+			out[i] = 0
+			continue
+		}
+		condP := probs[i] / remainingP
+		if condP > 1 {
+			condP = 1
+		}
+		bbs.prOne = condP
+		xi := bbs.SampleCount(remaining)
+		out[i] = xi
+		remaining -= xi
+		remainingP -= probs[i]
+	}
+	out[len(probs)-1] = remaining
+}
@@ -0,0 +1,51 @@
+package psampl_test
+
+import (
+	"math"
+	"math/rand"
+	"psampl"
+	"testing"
+)
+
+func TestBiasBitSampleCount(t *testing.T) {
+	seed := int64(1)
+	rsrc := rand.New(rand.NewSource(seed))
+	for _, pr := range []float64{0.01, 0.1, 0.5, 0.9} {
+		bbs := psampl.NewBiasBitSource(pr, rsrc)
+		const n = 5000
+		const trials = 200
+		total := 0
+		for i := 0; i < trials; i++ {
+			total += bbs.SampleCount(n)
+		}
+		got := float64(total) / float64(trials*n)
+		if math.Abs(got-pr) > errEps {
+			t.Errorf("obtained prob %f and should be %f, for pr=%f", got, pr, pr)
+		}
+	}
+}
+
+func TestSampleMulti(t *testing.T) {
+	for _, pr := range prs {
+		d, err := psampl.NewDistrib(pr)
+		if err != nil {
+			t.Fatalf("could not build Distrib %s:", err)
+		}
+		bs := d.NewPrngSampl(1)
+		const n = 100000
+		out := make([]int, len(pr))
+		bs.SampleMulti(n, out)
+
+		sum := 0
+		for i, c := range out {
+			sum += c
+			p := float64(c) / float64(n)
+			if math.Abs(p-pr[i]) > errEps {
+				t.Errorf("obtained prob %f and should be %f, in prs: %v", p, pr[i], pr)
+			}
+		}
+		if sum != n {
+			t.Errorf("counts should sum to n=%d, got %d", n, sum)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package psampl
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"math/rand"
+	"sync"
+)
+
+// chachaBlock computes one 64-byte ChaCha20 block (RFC 8439's 12-byte-nonce
+// variant) for key, block counter and nonce, running rounds/2 double
+// rounds (rounds is 8, 12 or 20, giving ChaCha8/12/20).
+func chachaBlock(key [8]uint32, counter uint32, nonce [3]uint32, rounds int) [64]byte {
+	x := [16]uint32{
+		0x61707865, 0x3320646e, 0x79622d32, 0x6b206574,
+		key[0], key[1], key[2], key[3],
+		key[4], key[5], key[6], key[7],
+		counter, nonce[0], nonce[1], nonce[2],
+	}
+	work := x
+	for i := 0; i < rounds/2; i++ {
+		chachaQuarterRound(&work[0], &work[4], &work[8], &work[12])
+		chachaQuarterRound(&work[1], &work[5], &work[9], &work[13])
+		chachaQuarterRound(&work[2], &work[6], &work[10], &work[14])
+		chachaQuarterRound(&work[3], &work[7], &work[11], &work[15])
+		chachaQuarterRound(&work[0], &work[5], &work[10], &work[15])
+		chachaQuarterRound(&work[1], &work[6], &work[11], &work[12])
+		chachaQuarterRound(&work[2], &work[7], &work[8], &work[13])
+		chachaQuarterRound(&work[3], &work[4], &work[9], &work[14])
+	}
+
+	var out [64]byte
+	for i := range work {
+		binary.LittleEndian.PutUint32(out[4*i:], work[i]+x[i])
+	}
+	return out
+}
+
+func chachaQuarterRound(a, b, c, d *uint32) {
+	*a += *b
+	*d ^= *a
+	*d = bits.RotateLeft32(*d, 16)
+	*c += *d
+	*b ^= *c
+	*b = bits.RotateLeft32(*b, 12)
+	*a += *b
+	*d ^= *a
+	*d = bits.RotateLeft32(*d, 8)
+	*c += *d
+	*b ^= *c
+	*b = bits.RotateLeft32(*b, 7)
+}
+
+// A ChaChaSource is a rand.Source64 backed by a ChaCha20 (or reduced-round
+// ChaCha8/ChaCha12) keystream, mirroring rand_chacha's ChaCha8/12/20Rng.
+// Unlike NewCryptoSampl's cryptorand.Source, a ChaChaSource is fully
+// seedable and reproducible: the same seed and nonce always produce the
+// same stream of samples, which is what makes it useful for testable
+// security-sensitive Monte Carlo, for differential-privacy mechanisms that
+// require auditable randomness, and for deterministic replay of sampled
+// byte streams from BiasSource.Read.
+//
+// A ChaChaSource is safe for concurrent use by multiple goroutines.
+type ChaChaSource struct {
+	mu     sync.Mutex
+	key    [8]uint32
+	nonce  [3]uint32
+	rounds int
+
+	counter uint32
+	// MaxBlocks bounds how many 64-byte blocks are drawn from a single
+	// key/nonce before the source rekeys itself from its own keystream,
+	// extending the usable stream past the 32-bit block counter's
+	// natural wraparound. Defaults to math.MaxUint32.
+	MaxBlocks uint32
+
+	block [64]byte
+	pos   int
+}
+
+// NewChaChaSource creates a ChaChaSource seeded with seed and nonce,
+// running the full 20-round ChaCha20.
+func NewChaChaSource(seed [32]byte, nonce [12]byte) *ChaChaSource {
+	return newChaChaSource(seed, nonce, 20)
+}
+
+// NewChaCha8Source creates a ChaChaSource running the reduced-round
+// ChaCha8, trading cryptographic margin for throughput.
+func NewChaCha8Source(seed [32]byte, nonce [12]byte) *ChaChaSource {
+	return newChaChaSource(seed, nonce, 8)
+}
+
+// NewChaCha12Source creates a ChaChaSource running the reduced-round
+// ChaCha12.
+func NewChaCha12Source(seed [32]byte, nonce [12]byte) *ChaChaSource {
+	return newChaChaSource(seed, nonce, 12)
+}
+
+func newChaChaSource(seed [32]byte, nonce [12]byte, rounds int) *ChaChaSource {
+	cs := &ChaChaSource{
+		rounds:    rounds,
+		MaxBlocks: 1<<32 - 1,
+	}
+	cs.rekeyWith(seed, nonce)
+	return cs
+}
+
+func (cs *ChaChaSource) rekeyWith(seed [32]byte, nonce [12]byte) {
+	for i := 0; i < 8; i++ {
+		cs.key[i] = binary.LittleEndian.Uint32(seed[4*i:])
+	}
+	for i := 0; i < 3; i++ {
+		cs.nonce[i] = binary.LittleEndian.Uint32(nonce[4*i:])
+	}
+	cs.counter = 0
+	cs.pos = 64 // force a refill on the next read
+}
+
+// rekey derives a fresh key and nonce from the current keystream position
+// and resets the block counter, extending the source past a single
+// key/nonce's MaxBlocks budget without ever repeating output.
+func (cs *ChaChaSource) rekey() {
+	blk := chachaBlock(cs.key, cs.counter, cs.nonce, cs.rounds)
+	var seed [32]byte
+	var nonce [12]byte
+	copy(seed[:], blk[:32])
+	copy(nonce[:], blk[32:44])
+	cs.rekeyWith(seed, nonce)
+}
+
+func (cs *ChaChaSource) refillLocked() {
+	if cs.counter >= cs.MaxBlocks {
+		cs.rekey()
+	}
+	cs.block = chachaBlock(cs.key, cs.counter, cs.nonce, cs.rounds)
+	cs.counter++
+	cs.pos = 0
+}
+
+// Uint64 returns the next 8 bytes of the keystream as a little-endian
+// uint64.
+func (cs *ChaChaSource) Uint64() uint64 {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.pos+8 > 64 {
+		cs.refillLocked()
+	}
+	v := binary.LittleEndian.Uint64(cs.block[cs.pos:])
+	cs.pos += 8
+	return v
+}
+
+// Int63 implements rand.Source by returning the top 63 bits of Uint64.
+func (cs *ChaChaSource) Int63() int64 {
+	return int64(cs.Uint64() >> 1)
+}
+
+// Seed implements rand.Source for interoperability with code that only
+// knows about int64 seeds: it expands seed into a 256-bit key (via
+// splitmix64) and a zero nonce. Prefer constructing a new ChaChaSource
+// with NewChaChaSource when a full 32-byte seed and 12-byte nonce are
+// available.
+func (cs *ChaChaSource) Seed(seed int64) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	var key [32]byte
+	x := uint64(seed)
+	for i := 0; i < 4; i++ {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+		binary.LittleEndian.PutUint64(key[8*i:], z)
+	}
+	var nonce [12]byte
+	cs.rekeyWith(key, nonce)
+}
+
+// NewChaChaSampl is a helper function which creates a BiasSource out of
+// Distrib with a ChaCha20 keystream, seeded with seed and nonce, as origin
+// of the input samples. Unlike NewCryptoSampl, the resulting sample stream
+// is fully reproducible given the same seed and nonce.
+func (d *Distrib) NewChaChaSampl(seed [32]byte, nonce [12]byte) *BiasSource {
+	return d.NewBiasSource(rand.New(NewChaChaSource(seed, nonce)))
+}
+
+// NewChaCha8Sampl is like NewChaChaSampl but runs the reduced-round
+// ChaCha8, trading cryptographic margin for throughput.
+func (d *Distrib) NewChaCha8Sampl(seed [32]byte, nonce [12]byte) *BiasSource {
+	return d.NewBiasSource(rand.New(NewChaCha8Source(seed, nonce)))
+}
+
+// NewChaCha12Sampl is like NewChaChaSampl but runs the reduced-round
+// ChaCha12.
+func (d *Distrib) NewChaCha12Sampl(seed [32]byte, nonce [12]byte) *BiasSource {
+	return d.NewBiasSource(rand.New(NewChaCha12Source(seed, nonce)))
+}
@@ -0,0 +1,69 @@
+package psampl_test
+
+import (
+	"psampl"
+	"testing"
+)
+
+func TestChaChaSourceDeterministic(t *testing.T) {
+	var seed [32]byte
+	var nonce [12]byte
+	seed[0] = 42
+	nonce[0] = 7
+
+	cs1 := psampl.NewChaChaSource(seed, nonce)
+	cs2 := psampl.NewChaChaSource(seed, nonce)
+	for i := 0; i < 100; i++ {
+		a, b := cs1.Uint64(), cs2.Uint64()
+		if a != b {
+			t.Fatalf("same seed/nonce should reproduce the same stream: word %d got %d and %d", i, a, b)
+		}
+	}
+
+	seed[0] = 43
+	cs3 := psampl.NewChaChaSource(seed, nonce)
+	if cs3.Uint64() == cs1.Uint64() {
+		t.Fatal("different seeds should not produce identical output")
+	}
+}
+
+func TestChaCha8And12Sources(t *testing.T) {
+	var seed [32]byte
+	var nonce [12]byte
+	for _, rounds := range []func([32]byte, [12]byte) *psampl.ChaChaSource{
+		psampl.NewChaCha8Source,
+		psampl.NewChaCha12Source,
+		psampl.NewChaChaSource,
+	} {
+		cs := rounds(seed, nonce)
+		seen := make(map[uint64]bool)
+		for i := 0; i < 100; i++ {
+			v := cs.Uint64()
+			if seen[v] {
+				continue // collisions are possible, just not expected to be constant
+			}
+			seen[v] = true
+		}
+		if len(seen) < 90 {
+			t.Errorf("expected mostly-distinct keystream words, got only %d distinct out of 100", len(seen))
+		}
+	}
+}
+
+func TestChaChaSampl(t *testing.T) {
+	pr := []float64{0.5, 0.5}
+	d, err := psampl.NewDistrib(pr)
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	var seed [32]byte
+	var nonce [12]byte
+	bs1 := d.NewChaChaSampl(seed, nonce)
+	bs2 := d.NewChaChaSampl(seed, nonce)
+	for i := 0; i < 1000; i++ {
+		a, b := bs1.SampleInt(), bs2.SampleInt()
+		if a != b {
+			t.Fatalf("same seed/nonce should reproduce the same stream: sample %d got %d and %d", i, a, b)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Package dist implements continuous probability distribution samplers
+// (Normal, Exponential, Gamma, Poisson) on top of the same *rand.Rand
+// sources used by psampl.Distrib.
+//
+// Each distribution type is a plain parameter struct whose Sample method
+// is stateless, so it can be used directly against any *rand.Rand. For
+// streaming use, attach a source with NewNormal/NewExponential/NewGamma/
+// NewPoisson (mirroring psampl's NewBiasSource) and call Read, which packs
+// samples the same way psampl.BiasSource.Read does.
+package dist
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+
+	"psampl"
+)
+
+// Normal represents a normal (Gaussian) distribution with mean Mu and
+// standard deviation Sigma.
+type Normal struct {
+	Mu    float64
+	Sigma float64
+	rsrc  *rand.Rand
+}
+
+// NewNormal attaches rsrc to a Normal distribution so it can be used
+// with Read.
+func NewNormal(mu, sigma float64, rsrc *rand.Rand) *Normal {
+	return &Normal{Mu: mu, Sigma: sigma, rsrc: rsrc}
+}
+
+// Sample draws one value from the normal distribution using rsrc.
+// math/rand's NormFloat64 already implements the Ziggurat algorithm, so
+// Sample only has to rescale its output to Mu/Sigma.
+func (n Normal) Sample(rsrc *rand.Rand) float64 {
+	return rsrc.NormFloat64()*n.Sigma + n.Mu
+}
+
+// PDF returns the probability density of the normal distribution at x.
+func (n Normal) PDF(x float64) float64 {
+	z := (x - n.Mu) / n.Sigma
+	return math.Exp(-0.5*z*z) / (n.Sigma * math.Sqrt(2*math.Pi))
+}
+
+// Read fills p with packed IEEE-754 big-endian float64 samples drawn from
+// the source attached by NewNormal. Each sample occupies 8 bytes, mirroring
+// the psampl.BiasSource.Read convention.
+func (n *Normal) Read(p []byte) (int, error) {
+	return readFloat64s(p, n.rsrc, n.Sample)
+}
+
+// Exponential represents an exponential distribution with rate Lambda.
+type Exponential struct {
+	Lambda float64
+	rsrc   *rand.Rand
+}
+
+// NewExponential attaches rsrc to an Exponential distribution so it can be
+// used with Read.
+func NewExponential(lambda float64, rsrc *rand.Rand) *Exponential {
+	return &Exponential{Lambda: lambda, rsrc: rsrc}
+}
+
+// Sample draws one value from the exponential distribution using rsrc, via
+// math/rand's Ziggurat-based ExpFloat64.
+func (e Exponential) Sample(rsrc *rand.Rand) float64 {
+	return rsrc.ExpFloat64() / e.Lambda
+}
+
+// PDF returns the probability density of the exponential distribution at x.
+func (e Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return e.Lambda * math.Exp(-e.Lambda*x)
+}
+
+// Read fills p with packed IEEE-754 big-endian float64 samples, mirroring
+// the psampl.BiasSource.Read convention.
+func (e *Exponential) Read(p []byte) (int, error) {
+	return readFloat64s(p, e.rsrc, e.Sample)
+}
+
+// Gamma represents a gamma distribution with shape Shape and scale Scale.
+type Gamma struct {
+	Shape float64
+	Scale float64
+	rsrc  *rand.Rand
+}
+
+// NewGamma attaches rsrc to a Gamma distribution so it can be used with
+// Read.
+func NewGamma(shape, scale float64, rsrc *rand.Rand) *Gamma {
+	return &Gamma{Shape: shape, Scale: scale, rsrc: rsrc}
+}
+
+// Sample draws one value from the gamma distribution using rsrc, via the
+// Marsaglia-Tsang method for Shape>=1, boosted by the Ahrens-Dieter
+// transform (sample Gamma(Shape+1) and scale down by U^(1/Shape)) for
+// Shape<1.
+func (g Gamma) Sample(rsrc *rand.Rand) float64 {
+	if g.Shape >= 1 {
+		return marsagliaTsang(g.Shape, rsrc) * g.Scale
+	}
+	x := marsagliaTsang(g.Shape+1, rsrc)
+	u := rsrc.Float64()
+	return x * math.Pow(u, 1.0/g.Shape) * g.Scale
+}
+
+// PDF returns the probability density of the gamma distribution at x.
+func (g Gamma) PDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return math.Pow(x, g.Shape-1) * math.Exp(-x/g.Scale) / (math.Gamma(g.Shape) * math.Pow(g.Scale, g.Shape))
+}
+
+// Read fills p with packed IEEE-754 big-endian float64 samples, mirroring
+// the psampl.BiasSource.Read convention.
+func (g *Gamma) Read(p []byte) (int, error) {
+	return readFloat64s(p, g.rsrc, g.Sample)
+}
+
+// marsagliaTsang draws a Gamma(shape, 1) sample for shape>=1 using the
+// Marsaglia-Tsang squeeze method.
+func marsagliaTsang(shape float64, rsrc *rand.Rand) float64 {
+	d := shape - 1.0/3.0
+	c := 1.0 / math.Sqrt(9*d)
+	for {
+		var x, v float64
+		for {
+			x = rsrc.NormFloat64()
+			v = 1 + c*x
+			if v > 0 {
+				break
+			}
+		}
+		v = v * v * v
+		u := rsrc.Float64()
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Poisson represents a Poisson distribution with rate Lambda.
+type Poisson struct {
+	Lambda float64
+	rsrc   *rand.Rand
+}
+
+// NewPoisson attaches rsrc to a Poisson distribution so it can be used with
+// Read.
+func NewPoisson(lambda float64, rsrc *rand.Rand) *Poisson {
+	return &Poisson{Lambda: lambda, rsrc: rsrc}
+}
+
+// Sample draws one value from the Poisson distribution using rsrc: Knuth's
+// multiplication algorithm for Lambda<30, and Hormann's PTRS (transformed
+// rejection with a squeeze) for larger Lambda, where the per-sample cost of
+// Knuth's algorithm grows linearly with Lambda.
+func (p Poisson) Sample(rsrc *rand.Rand) int {
+	if p.Lambda < 30 {
+		return poissonKnuth(p.Lambda, rsrc)
+	}
+	return poissonPTRS(p.Lambda, rsrc)
+}
+
+// Read fills p with packed big-endian uint64 samples, 8 bytes each,
+// mirroring the psampl.BiasSource.Read convention.
+func (p *Poisson) Read(b []byte) (int, error) {
+	n := len(b) - len(b)%8
+	for i := 0; i < n; i += 8 {
+		binary.BigEndian.PutUint64(b[i:], uint64(p.Sample(p.rsrc)))
+	}
+	return n, nil
+}
+
+func poissonKnuth(lambda float64, rsrc *rand.Rand) int {
+	l := math.Exp(-lambda)
+	k := 0
+	prod := 1.0
+	for {
+		k++
+		prod *= rsrc.Float64()
+		if prod <= l {
+			break
+		}
+	}
+	return k - 1
+}
+
+func poissonPTRS(lambda float64, rsrc *rand.Rand) int {
+	b := 0.931 + 2.53*math.Sqrt(lambda)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := rsrc.Float64() - 0.5
+		v := rsrc.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		logGammaK1, _ := math.Lgamma(k + 1)
+		if math.Log(v*invAlpha/(a/(us*us)+b)) <= -lambda+k*math.Log(lambda)-logGammaK1 {
+			return int(k)
+		}
+	}
+}
+
+// readFloat64s fills p with samples drawn from sample(rsrc), packed as
+// IEEE-754 big-endian float64s.
+func readFloat64s(p []byte, rsrc *rand.Rand, sample func(*rand.Rand) float64) (int, error) {
+	n := len(p) - len(p)%8
+	for i := 0; i < n; i += 8 {
+		binary.BigEndian.PutUint64(p[i:], math.Float64bits(sample(rsrc)))
+	}
+	return n, nil
+}
+
+// DistribFromPDF builds a discrete psampl.Distrib approximating the
+// continuous density pdf over [lo, hi], by quantizing it into n equal-width
+// bins (truncating the tails outside the interval) and handing the
+// resulting histogram to psampl.NewDistrib, which builds the Vose's alias
+// tables used for O(1) generation.
+func DistribFromPDF(pdf func(x float64) float64, lo, hi float64, n int) (*psampl.Distrib, error) {
+	width := (hi - lo) / float64(n)
+	prob := make([]float64, n)
+	sum := 0.0
+	for i := range prob {
+		x := lo + (float64(i)+0.5)*width
+		prob[i] = pdf(x) * width
+		sum += prob[i]
+	}
+	for i := range prob {
+		prob[i] /= sum
+	}
+	return psampl.NewDistrib(prob)
+}
@@ -0,0 +1,158 @@
+package dist_test
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+
+	"psampl/dist"
+)
+
+const (
+	nTestSamples = 200000
+	meanEps      = 0.05
+)
+
+func mean(xs []float64) float64 {
+	s := 0.0
+	for _, x := range xs {
+		s += x
+	}
+	return s / float64(len(xs))
+}
+
+// unpackFloat64s unpacks p, as packed by Read, into the float64s it encodes.
+func unpackFloat64s(p []byte) []float64 {
+	xs := make([]float64, len(p)/8)
+	for i := range xs {
+		xs[i] = math.Float64frombits(binary.BigEndian.Uint64(p[i*8:]))
+	}
+	return xs
+}
+
+func TestNormalSample(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	n := dist.Normal{Mu: 2.0, Sigma: 0.5}
+	xs := make([]float64, nTestSamples)
+	for i := range xs {
+		xs[i] = n.Sample(rsrc)
+	}
+	if m := mean(xs); math.Abs(m-n.Mu) > meanEps {
+		t.Errorf("obtained mean %f and should be close to %f", m, n.Mu)
+	}
+}
+
+func TestNormalRead(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	n := dist.NewNormal(2.0, 0.5, rsrc)
+	p := make([]byte, 8*nTestSamples)
+	nb, err := n.Read(p)
+	if err != nil || nb != len(p) {
+		t.Fatal("read failed")
+	}
+	if m := mean(unpackFloat64s(p)); math.Abs(m-2.0) > meanEps {
+		t.Errorf("obtained mean %f and should be close to %f", m, 2.0)
+	}
+}
+
+func TestExponentialSample(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	e := dist.Exponential{Lambda: 2.0}
+	xs := make([]float64, nTestSamples)
+	for i := range xs {
+		xs[i] = e.Sample(rsrc)
+	}
+	want := 1.0 / e.Lambda
+	if m := mean(xs); math.Abs(m-want) > meanEps {
+		t.Errorf("obtained mean %f and should be close to %f", m, want)
+	}
+}
+
+func TestExponentialRead(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	lambda := 2.0
+	e := dist.NewExponential(lambda, rsrc)
+	p := make([]byte, 8*nTestSamples)
+	nb, err := e.Read(p)
+	if err != nil || nb != len(p) {
+		t.Fatal("read failed")
+	}
+	want := 1.0 / lambda
+	if m := mean(unpackFloat64s(p)); math.Abs(m-want) > meanEps {
+		t.Errorf("obtained mean %f and should be close to %f", m, want)
+	}
+}
+
+func TestGammaSample(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	for _, g := range []dist.Gamma{{Shape: 0.5, Scale: 2.0}, {Shape: 3.0, Scale: 2.0}} {
+		xs := make([]float64, nTestSamples)
+		for i := range xs {
+			xs[i] = g.Sample(rsrc)
+		}
+		want := g.Shape * g.Scale
+		if m := mean(xs); math.Abs(m-want) > meanEps*want {
+			t.Errorf("obtained mean %f and should be close to %f, for %+v", m, want, g)
+		}
+	}
+}
+
+func TestGammaRead(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	shape, scale := 3.0, 2.0
+	g := dist.NewGamma(shape, scale, rsrc)
+	p := make([]byte, 8*nTestSamples)
+	nb, err := g.Read(p)
+	if err != nil || nb != len(p) {
+		t.Fatal("read failed")
+	}
+	want := shape * scale
+	if m := mean(unpackFloat64s(p)); math.Abs(m-want) > meanEps*want {
+		t.Errorf("obtained mean %f and should be close to %f", m, want)
+	}
+}
+
+func TestPoissonSample(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	for _, lambda := range []float64{5, 50} {
+		p := dist.Poisson{Lambda: lambda}
+		xs := make([]float64, nTestSamples)
+		for i := range xs {
+			xs[i] = float64(p.Sample(rsrc))
+		}
+		if m := mean(xs); math.Abs(m-lambda) > meanEps*lambda {
+			t.Errorf("obtained mean %f and should be close to %f, for lambda %f", m, lambda, lambda)
+		}
+	}
+}
+
+func TestPoissonRead(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	lambda := 50.0
+	p := dist.NewPoisson(lambda, rsrc)
+	b := make([]byte, 8*nTestSamples)
+	nb, err := p.Read(b)
+	if err != nil || nb != len(b) {
+		t.Fatal("read failed")
+	}
+	xs := make([]float64, len(b)/8)
+	for i := range xs {
+		xs[i] = float64(binary.BigEndian.Uint64(b[i*8:]))
+	}
+	if m := mean(xs); math.Abs(m-lambda) > meanEps*lambda {
+		t.Errorf("obtained mean %f and should be close to %f, for lambda %f", m, lambda, lambda)
+	}
+}
+
+func TestDistribFromPDF(t *testing.T) {
+	n := dist.Normal{Mu: 0, Sigma: 1}
+	d, err := dist.DistribFromPDF(n.PDF, -4, 4, 200)
+	if err != nil {
+		t.Fatalf("could not build Distrib from pdf: %s", err)
+	}
+	bs := d.NewPrngSampl(1)
+	for i := 0; i < 1000; i++ {
+		bs.SampleInt()
+	}
+}
@@ -0,0 +1,135 @@
+package psampl
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"math/rand"
+)
+
+// A HashDRBGSource is a rand.Source64 producing a deterministic keystream
+// in SipHash-2-4-OFB mode: each output block is SipHash-2-4(key, iv), and
+// the next iv is the previous output. Two parties who share a seed obtain
+// byte-for-byte identical streams, which DistribFromSeed uses to let them
+// derive identical sampling tables without exchanging anything but the
+// seed itself (the obfs4/ScrambleSuit use case).
+type HashDRBGSource struct {
+	k0, k1 uint64
+	iv     uint64
+}
+
+// NewHashDRBGSource returns a HashDRBGSource wrapped in a *rand.Rand,
+// keyed with the first 16 bytes of seed and initialized with the next 8
+// bytes as the first iv.
+func NewHashDRBGSource(seed [32]byte) *rand.Rand {
+	s := &HashDRBGSource{
+		k0: binary.BigEndian.Uint64(seed[0:8]),
+		k1: binary.BigEndian.Uint64(seed[8:16]),
+		iv: binary.BigEndian.Uint64(seed[16:24]),
+	}
+	return rand.New(s)
+}
+
+// Uint64 returns the next DRBG output block and feeds it back in as the
+// next iv.
+func (s *HashDRBGSource) Uint64() uint64 {
+	var iv [8]byte
+	binary.BigEndian.PutUint64(iv[:], s.iv)
+	out := sipHash24(s.k0, s.k1, iv[:])
+	s.iv = out
+	return out
+}
+
+// Int63 implements rand.Source by returning the top 63 bits of Uint64.
+func (s *HashDRBGSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed reseeds the DRBG, reusing seed as both the new iv and (doubled) the
+// new key, for interoperability with code that only knows about int64
+// seeds. Prefer NewHashDRBGSource when a full 32-byte seed is available.
+func (s *HashDRBGSource) Seed(seed int64) {
+	s.k0 = uint64(seed)
+	s.k1 = uint64(seed)
+	s.iv = uint64(seed)
+}
+
+// sipHash24 computes SipHash-2-4 (2 compression rounds, 4 finalization
+// rounds) of data under key (k0, k1), as specified by Aumasson and
+// Bernstein.
+func sipHash24(k0, k1 uint64, data []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	b := uint64(len(data)) << 56
+
+	for len(data) >= 8 {
+		mi := binary.LittleEndian.Uint64(data)
+		v3 ^= mi
+		sipRound(&v0, &v1, &v2, &v3)
+		sipRound(&v0, &v1, &v2, &v3)
+		v0 ^= mi
+		data = data[8:]
+	}
+	for i, c := range data {
+		b |= uint64(c) << uint(8*i)
+	}
+
+	v3 ^= b
+	sipRound(&v0, &v1, &v2, &v3)
+	sipRound(&v0, &v1, &v2, &v3)
+	v0 ^= b
+
+	v2 ^= 0xff
+	sipRound(&v0, &v1, &v2, &v3)
+	sipRound(&v0, &v1, &v2, &v3)
+	sipRound(&v0, &v1, &v2, &v3)
+	sipRound(&v0, &v1, &v2, &v3)
+
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+func sipRound(v0, v1, v2, v3 *uint64) {
+	*v0 += *v1
+	*v1 = bits.RotateLeft64(*v1, 13)
+	*v1 ^= *v0
+	*v0 = bits.RotateLeft64(*v0, 32)
+	*v2 += *v3
+	*v3 = bits.RotateLeft64(*v3, 16)
+	*v3 ^= *v2
+	*v0 += *v3
+	*v3 = bits.RotateLeft64(*v3, 21)
+	*v3 ^= *v0
+	*v2 += *v1
+	*v1 = bits.RotateLeft64(*v1, 17)
+	*v1 ^= *v2
+	*v2 = bits.RotateLeft64(*v2, 32)
+}
+
+// DistribFromSeed draws n weights from a HashDRBGSource keyed with seed
+// (via a Dirichlet-like normalization: n independent Exp(1) draws, divided
+// by their sum) and builds the resulting alias tables with NewDistrib. Two
+// parties who call DistribFromSeed with the same seed and n derive
+// identical Distrib tables from that seed alone.
+func DistribFromSeed(seed [32]byte, n int) *Distrib {
+	rsrc := NewHashDRBGSource(seed)
+	weights := make([]float64, n)
+	sum := 0.0
+	for i := range weights {
+		w := rsrc.ExpFloat64()
+		weights[i] = w
+		sum += w
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	d, err := NewDistrib(weights)
+	if err != nil {
+		// Normalized weights always sum to 1 up to floating point error,
+		// which NewDistrib already tolerates via pSmall.
+		panic(err)
+	}
+	return d
+}
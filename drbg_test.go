@@ -0,0 +1,49 @@
+package psampl_test
+
+import (
+	"bytes"
+	"psampl"
+	"testing"
+)
+
+func TestHashDRBGSourceDeterministic(t *testing.T) {
+	var seed [32]byte
+	seed[0] = 9
+	seed[20] = 3
+
+	r1 := psampl.NewHashDRBGSource(seed)
+	r2 := psampl.NewHashDRBGSource(seed)
+	for i := 0; i < 1000; i++ {
+		a, b := r1.Uint64(), r2.Uint64()
+		if a != b {
+			t.Fatalf("same seed should reproduce the same stream: word %d got %d and %d", i, a, b)
+		}
+	}
+
+	seed[0] = 10
+	r3 := psampl.NewHashDRBGSource(seed)
+	if r3.Uint64() == r1.Uint64() {
+		t.Fatal("different seeds should not produce identical output")
+	}
+}
+
+func TestDistribFromSeed(t *testing.T) {
+	var seed [32]byte
+	seed[5] = 0x42
+
+	d1 := psampl.DistribFromSeed(seed, 8)
+	d2 := psampl.DistribFromSeed(seed, 8)
+	buf1, _ := d1.MarshalBinary()
+	buf2, _ := d2.MarshalBinary()
+	if !bytes.Equal(buf1, buf2) {
+		t.Fatal("DistribFromSeed with the same seed should produce identical tables")
+	}
+
+	bs := d1.NewPrngSampl(1)
+	for i := 0; i < 1000; i++ {
+		n := bs.SampleInt()
+		if n < 0 || n >= 8 {
+			t.Fatalf("sample %d out of range [0,8)", n)
+		}
+	}
+}
@@ -6,6 +6,11 @@
 // a random number generator source. BiasSource is safe for concurrent use by multiple goroutines given
 // that the source of random numbers is itself safe.
 //
+// NewBiasSource takes a *rand.Rand, but NewBiasSourceFrom also accepts a
+// rand.Source, a rand.Source64 or a raw io.Reader, so a BiasSource can be
+// fed directly from crypto/rand.Reader, an opened /dev/urandom, or a
+// network-sourced entropy stream without paying for a *rand.Rand wrapper.
+//
 // The algorithm used is Vose's alias method
 // https://web.archive.org/web/20131029203736/http://web.eecs.utk.edu/~vose/Publications/random.pdf
 // which is O(1) in generation time and O(1) in its use of input random numbers, but which has a
@@ -28,8 +33,9 @@ const (
 // A Distrib represents a probability distribution and can be later used
 // to create biased sources with that probability distribution
 type Distrib struct {
-	prob  []float64
-	alias []int
+	prob     []float64
+	alias    []int
+	origProb []float64
 }
 
 const pSmall = 1e-10
@@ -97,8 +103,9 @@ func NewDistrib(prob []float64) (d *Distrib, err error) {
 	}
 
 	d = &Distrib{
-		prob:  make([]float64, len(pr)),
-		alias: make([]int, len(pr)),
+		prob:     make([]float64, len(pr)),
+		alias:    make([]int, len(pr)),
+		origProb: append([]float64(nil), prob...),
 	}
 
 	for len(probT.small) != 0 && len(probT.large) != 0 {
@@ -122,31 +129,47 @@ func NewDistrib(prob []float64) (d *Distrib, err error) {
 type BiasSource struct {
 	d           *Distrib
 	nBytesSampl int
-	rsrc        *rand.Rand
+	rsrc        uniform
 }
 
 // NewBiasSource biases a random source (expected to be uniformly distributed) using Distrib
 // and creates BiasSource which can be used to obtain samples.
 func (d *Distrib) NewBiasSource(rsrc *rand.Rand) *BiasSource {
+	return d.NewBiasSourceFrom(rsrc)
+}
+
+// NewBiasSourceFrom is like NewBiasSource but accepts any of a rand.Source,
+// a rand.Source64, a raw io.Reader (e.g. crypto/rand.Reader, an opened
+// /dev/urandom, or a network-sourced entropy stream), or anything already
+// implementing the internal uniform interface (which *rand.Rand does).
+// A rand.Source64 or a uniform is used directly, without a *rand.Rand
+// wrapper, and Read then does not allocate; an io.Reader source allocates
+// an 8-byte buffer on every Uint64 draw, so Read over it allocates in
+// proportion to len(p).
+func (d *Distrib) NewBiasSourceFrom(src interface{}) *BiasSource {
 	nb := bytesNeeded(len(d.prob))
-	bs := &BiasSource{
+	return &BiasSource{
 		d:           d,
 		nBytesSampl: nb,
-		rsrc:        rsrc,
+		rsrc:        asUniform(src),
 	}
-
-	return bs
 }
 
-func biasCoin(prOne float64, rsrc *rand.Rand) bool {
-	x := rsrc.Float64()
-	return x < prOne
+// biasCoin flips a coin biased towards true with probability prOne, by
+// comparing a single Uint64 draw against a fixed-point threshold instead of
+// converting to a float64 per flip.
+func biasCoin(prOne float64, rsrc uniform) bool {
+	if prOne >= 1.0 {
+		return true
+	}
+	threshold := uint64(prOne * maxUint64AsFloat)
+	return rsrc.Uint64() < threshold
 }
 
 // SampleInt returns one sample from BiasSource, encoded as an int.
 func (bs *BiasSource) SampleInt() (num int) {
 	nt := len(bs.d.prob)
-	i := bs.rsrc.Intn(nt)
+	i := uniformIntn(bs.rsrc, nt)
 
 	if biasCoin(bs.d.prob[i], bs.rsrc) {
 		num = i
@@ -193,7 +216,7 @@ func (d *Distrib) NewPrngSampl(seed int64) *BiasSource {
 
 type BiasBitSource struct {
 	prOne float64
-	rsrc  *rand.Rand
+	rsrc  uniform
 }
 
 // NewBiasBitSource creates a source from which to sample biased bits.
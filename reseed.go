@@ -0,0 +1,83 @@
+package psampl
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+)
+
+// A ReseedingSource wraps a math/rand.Source and periodically reseeds it
+// from crypto/rand, giving the wrapped source forward security: once an
+// attacker learns its state, only the bytes generated since the last
+// reseed are compromised. It is inspired by rand::rngs::adapter::
+// ReseedingRng from the rand crate.
+//
+// ReseedingSource is safe for concurrent use by multiple goroutines.
+type ReseedingSource struct {
+	mu        sync.Mutex
+	src       rand.Source
+	threshold int64
+	consumed  int64
+}
+
+// NewReseedingSource wraps src, reseeding it from crypto/rand once
+// threshold bytes have been drawn from it.
+func NewReseedingSource(src rand.Source, threshold int64) *ReseedingSource {
+	return &ReseedingSource{
+		src:       src,
+		threshold: threshold,
+	}
+}
+
+// Int63 returns the next pseudo-random number from the wrapped source,
+// reseeding it first if threshold bytes have been consumed since the last
+// reseed.
+func (rs *ReseedingSource) Int63() int64 {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.consumed >= rs.threshold {
+		rs.reseedLocked()
+	}
+	rs.consumed += 8
+	return rs.src.Int63()
+}
+
+// Seed reseeds the wrapped source directly and resets the consumption
+// counter.
+func (rs *ReseedingSource) Seed(seed int64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.src.Seed(seed)
+	rs.consumed = 0
+}
+
+// reseedLocked pulls 8 bytes from crypto/rand and reseeds the wrapped
+// source. rs.mu must be held.
+func (rs *ReseedingSource) reseedLocked() {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		// crypto/rand.Read only fails if the OS entropy source is
+		// broken, in which case there is nothing sane left to do.
+		panic(err)
+	}
+	rs.src.Seed(int64(binary.BigEndian.Uint64(b[:])))
+	rs.consumed = 0
+}
+
+// NewReseedingSampl is a helper function which creates a BiasSource backed
+// by a ReseedingSource: it has the throughput of NewPrngSampl, since most
+// samples come from a fast math/rand source, but periodically reseeds that
+// source from crypto/rand once threshold bytes have been drawn from it,
+// giving forward security closer to NewCryptoSampl. This is particularly
+// useful for long-running Read streams where NewCryptoSampl is too slow.
+func (d *Distrib) NewReseedingSampl(threshold int64) *BiasSource {
+	var seed [8]byte
+	if _, err := cryptorand.Read(seed[:]); err != nil {
+		panic(err)
+	}
+	inner := rand.NewSource(int64(binary.BigEndian.Uint64(seed[:])))
+	rs := NewReseedingSource(inner, threshold)
+	rsrc := rand.New(rs)
+	return d.NewBiasSource(rsrc)
+}
@@ -0,0 +1,34 @@
+package psampl_test
+
+import (
+	"math/rand"
+	"psampl"
+	"testing"
+)
+
+func TestReseedingSource(t *testing.T) {
+	inner := rand.NewSource(1)
+	rs := psampl.NewReseedingSource(inner, 64)
+	for i := 0; i < 1000; i++ {
+		rs.Int63()
+	}
+}
+
+func TestReseedingSampl(t *testing.T) {
+	pr := []float64{0.5, 0.5}
+	d, err := psampl.NewDistrib(pr)
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	bs := d.NewReseedingSampl(64)
+	nf := make([]float64, len(pr))
+	for i := 0; i < nTestSampleInts; i++ {
+		nf[bs.SampleInt()]++
+	}
+	for i, n := range nf {
+		p := n / nTestSampleInts
+		if p < pr[i]-errEps || p > pr[i]+errEps {
+			t.Errorf("obtained prob %f and should be %f", p, pr[i])
+		}
+	}
+}
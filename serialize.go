@@ -0,0 +1,83 @@
+package psampl
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// distribEncodingWidth is the number of bytes each stored value (an
+// origProb, prob or alias entry) occupies in a Distrib's binary encoding.
+const distribEncodingWidth = 8
+
+// MarshalBinary encodes d's original probabilities together with its
+// precomputed Vose's alias tables, so that a Distrib built once can be
+// shipped to or cached by another process without repeating the O(N)
+// NewDistrib setup. Use UnmarshalBinary to decode it back.
+func (d *Distrib) MarshalBinary() ([]byte, error) {
+	n := len(d.prob)
+	buf := make([]byte, 4+3*distribEncodingWidth*n)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(n))
+
+	off := 4
+	for _, p := range d.origProb {
+		binary.BigEndian.PutUint64(buf[off:], math.Float64bits(p))
+		off += distribEncodingWidth
+	}
+	for _, p := range d.prob {
+		binary.BigEndian.PutUint64(buf[off:], math.Float64bits(p))
+		off += distribEncodingWidth
+	}
+	for _, a := range d.alias {
+		binary.BigEndian.PutUint64(buf[off:], uint64(int64(a)))
+		off += distribEncodingWidth
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into d, replacing
+// its alias tables.
+func (d *Distrib) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errors.New("psampl: truncated Distrib encoding")
+	}
+	n := int(binary.BigEndian.Uint32(data[0:4]))
+	want := 4 + 3*distribEncodingWidth*n
+	if len(data) != want {
+		return errors.New("psampl: Distrib encoding has the wrong length")
+	}
+
+	off := 4
+	origProb := make([]float64, n)
+	for i := range origProb {
+		origProb[i] = math.Float64frombits(binary.BigEndian.Uint64(data[off:]))
+		off += distribEncodingWidth
+	}
+	prob := make([]float64, n)
+	for i := range prob {
+		prob[i] = math.Float64frombits(binary.BigEndian.Uint64(data[off:]))
+		off += distribEncodingWidth
+	}
+	alias := make([]int, n)
+	for i := range alias {
+		alias[i] = int(int64(binary.BigEndian.Uint64(data[off:])))
+		off += distribEncodingWidth
+	}
+
+	d.origProb = origProb
+	d.prob = prob
+	d.alias = alias
+	return nil
+}
+
+// WriteTo writes d's binary encoding (see MarshalBinary) to w, implementing
+// io.WriterTo.
+func (d *Distrib) WriteTo(w io.Writer) (int64, error) {
+	buf, err := d.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
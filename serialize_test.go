@@ -0,0 +1,53 @@
+package psampl_test
+
+import (
+	"bytes"
+	"psampl"
+	"testing"
+)
+
+func TestDistribMarshalRoundtrip(t *testing.T) {
+	for _, pr := range prs {
+		d, err := psampl.NewDistrib(pr)
+		if err != nil {
+			t.Fatalf("could not build Distrib %s:", err)
+		}
+		buf, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %s", err)
+		}
+
+		var got psampl.Distrib
+		if err := got.UnmarshalBinary(buf); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %s", err)
+		}
+
+		bs := got.NewPrngSampl(1)
+		for i := 0; i < nTestSampleInts; i++ {
+			n := bs.SampleInt()
+			if n < 0 || n >= len(pr) {
+				t.Fatalf("sample %d out of range for pr %v", n, pr)
+			}
+		}
+	}
+}
+
+func TestDistribWriteTo(t *testing.T) {
+	d, err := psampl.NewDistrib(prs[0])
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	var buf bytes.Buffer
+	n, err := d.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %s", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d but wrote %d bytes", n, buf.Len())
+	}
+
+	var got psampl.Distrib
+	if err := got.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %s", err)
+	}
+}
@@ -0,0 +1,82 @@
+package psampl
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"math/rand"
+)
+
+// maxUint64AsFloat is 2^64, used to turn a probability into a fixed-point
+// threshold comparable against a single Uint64 draw.
+const maxUint64AsFloat = 1 << 64
+
+// uniform is the minimal source BiasSource and BiasBitSource need: a
+// uniformly distributed Uint64 (for biasCoin's fixed-point compare and for
+// picking an index) and a uniformly distributed Float64 in [0,1) (for the
+// binomial samplers in binomial.go, which still reason about real-valued
+// probabilities).
+// *rand.Rand already implements uniform.
+type uniform interface {
+	Uint64() uint64
+	Float64() float64
+}
+
+// source64Adapter turns a rand.Source64 into a uniform by deriving Float64
+// from the top 53 bits of a Uint64 draw.
+type source64Adapter struct {
+	src rand.Source64
+}
+
+func (a source64Adapter) Uint64() uint64 {
+	return a.src.Uint64()
+}
+
+func (a source64Adapter) Float64() float64 {
+	return float64(a.src.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// readerSource turns a raw io.Reader of entropy into a uniform by reading
+// 8 bytes per Uint64 draw. It assumes the reader never errors, which holds
+// for the entropy sources it's meant for: crypto/rand.Reader, an opened
+// /dev/urandom, or a blocking network-sourced entropy stream.
+type readerSource struct {
+	r io.Reader
+}
+
+func (rs readerSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := io.ReadFull(rs.r, b[:]); err != nil {
+		panic("psampl: reading from entropy source: " + err.Error())
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (rs readerSource) Float64() float64 {
+	return float64(rs.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// asUniform adapts src, which must be a uniform, a rand.Source64, a
+// rand.Source, or an io.Reader, into a uniform.
+func asUniform(src interface{}) uniform {
+	switch s := src.(type) {
+	case uniform:
+		return s
+	case rand.Source64:
+		return source64Adapter{s}
+	case rand.Source:
+		return rand.New(s)
+	case io.Reader:
+		return readerSource{s}
+	default:
+		panic("psampl: unsupported source type for NewBiasSourceFrom")
+	}
+}
+
+// uniformIntn returns a uniformly distributed int in [0, n), using Lemire's
+// multiply-high trick on a single Uint64 draw rather than Int63n's
+// rejection loop.
+func uniformIntn(rsrc uniform, n int) int {
+	hi, _ := bits.Mul64(rsrc.Uint64(), uint64(n))
+	return int(hi)
+}
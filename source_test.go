@@ -0,0 +1,50 @@
+package psampl_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	mrand "math/rand"
+	"psampl"
+	"testing"
+)
+
+func TestNewBiasSourceFromSource64(t *testing.T) {
+	d, err := psampl.NewDistrib(prs[1])
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	bs := d.NewBiasSourceFrom(mrand.NewSource(1))
+	for i := 0; i < nTestSampleInts; i++ {
+		bs.SampleInt()
+	}
+}
+
+func TestNewBiasSourceFromReader(t *testing.T) {
+	d, err := psampl.NewDistrib(prs[1])
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	bs := d.NewBiasSourceFrom(rand.Reader)
+	for i := 0; i < 1000; i++ {
+		n := bs.SampleInt()
+		if n != 0 && n != 1 {
+			t.Fatalf("sample %d out of range for %v", n, prs[1])
+		}
+	}
+}
+
+func TestNewBiasSourceFromFixedReader(t *testing.T) {
+	// a deterministic io.Reader that always reads zero bytes should
+	// always bias towards index 0.
+	d, err := psampl.NewDistrib(prs[1])
+	if err != nil {
+		t.Fatalf("could not build Distrib %s:", err)
+	}
+	zero := make([]byte, 8*nTestSampleInts)
+	bs := d.NewBiasSourceFrom(bytes.NewReader(zero))
+	for i := 0; i < nTestSampleInts; i++ {
+		if n := bs.SampleInt(); n != 0 {
+			t.Fatalf("expected index 0 from an all-zero entropy stream, got %d", n)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package psampl
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// A Zipf represents a Zipf (power-law) distribution over the integers
+// [0, imax], with P(k) proportional to (v+k)**(-s), exponent parameter S
+// (S>1) and shift parameter V (V>=1).
+// Building alias tables as Distrib does is infeasible for supports as
+// large as imax=2^31, so Zipf instead draws samples in O(1) expected time
+// and O(1) memory via the Walker rejection scheme used by
+// golang.org/x/exp/rand's Zipf generator.
+type Zipf struct {
+	imax uint64
+	v    float64
+	q    float64
+
+	oneminusQ    float64
+	oneminusQinv float64
+	hxm          float64
+	hx0minusHxm  float64
+	s1           float64
+
+	nBytesSampl int
+}
+
+// h computes H(x) = exp((1-q)*ln(v+x)) / (1-q).
+func (z *Zipf) h(x float64) float64 {
+	return math.Exp(z.oneminusQ*math.Log(z.v+x)) * z.oneminusQinv
+}
+
+// hinv computes the inverse of h: Hinv(x) = exp(ln((1-q)*x)/(1-q)) - v.
+func (z *Zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneminusQinv*math.Log(z.oneminusQ*x)) - z.v
+}
+
+// NewZipf returns a Zipf sampler over [0, imax] with exponent s (s>1) and
+// shift v (v>=1), precomputing q, hxm, hx0minusHxm and s1 the same way
+// golang.org/x/exp/rand/zipf.go does. As in that package, s<=1 or v<1 are
+// rejected: s=1 makes oneminusQinv infinite and NaN propagates from there
+// into hx0minusHxm/s1, which would make Sample loop forever.
+func NewZipf(s, v float64, imax uint64) (*Zipf, error) {
+	if s <= 1.0 {
+		return nil, errors.New("psampl: zipf exponent s must be > 1")
+	}
+	if v < 1.0 {
+		return nil, errors.New("psampl: zipf shift v must be >= 1")
+	}
+
+	z := &Zipf{
+		imax:        imax,
+		v:           v,
+		q:           s,
+		nBytesSampl: bytesNeeded(int(imax)),
+	}
+	z.oneminusQ = 1 - z.q
+	z.oneminusQinv = 1 / z.oneminusQ
+	z.hxm = z.h(float64(z.imax) + 0.5)
+	z.hx0minusHxm = z.h(0.5) - math.Exp(-z.q*math.Log(z.v)) - z.hxm
+	z.s1 = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1.0)))
+	return z, nil
+}
+
+// Sample returns one sample from the Zipf distribution. It maps a uniform
+// draw into the H-transformed domain, inverts H to get a candidate x,
+// rounds to the nearest integer k and accepts k either via the cheap
+// squeeze (k-x<=s1) or, failing that, the exact acceptance test;
+// otherwise it resamples.
+func (z *Zipf) Sample(rsrc *rand.Rand) uint64 {
+	var k float64
+	for {
+		u := z.hxm + rsrc.Float64()*z.hx0minusHxm
+		x := z.hinv(u)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s1 {
+			break
+		}
+		if u >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+	return uint64(k)
+}
+
+// A ZipfSource attaches a *rand.Rand to a Zipf distribution so that it can
+// be streamed with Read, mirroring how Distrib.NewBiasSource attaches a
+// source to a Distrib.
+type ZipfSource struct {
+	z    *Zipf
+	rsrc *rand.Rand
+}
+
+// NewSource creates a ZipfSource which can be used to obtain samples from
+// z using rsrc.
+func (z *Zipf) NewSource(rsrc *rand.Rand) *ZipfSource {
+	return &ZipfSource{z: z, rsrc: rsrc}
+}
+
+// Read fills p with packed big-endian Zipf samples. Each sample occupies
+// bytesNeeded(imax) bytes, mirroring the BiasSource.Read convention.
+func (zs *ZipfSource) Read(p []byte) (n int, err error) {
+	nb := zs.z.nBytesSampl
+	for i := 0; i < len(p); i += nb {
+		num := zs.z.Sample(zs.rsrc)
+		for j := 0; j < nb; j++ {
+			b := byte(num)
+			p[i+nb-j-1] = b
+			num >>= 8
+		}
+	}
+	return len(p), nil
+}
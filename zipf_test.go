@@ -0,0 +1,86 @@
+package psampl_test
+
+import (
+	"math"
+	"math/rand"
+	"psampl"
+	"testing"
+)
+
+func TestZipfSample(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	z, err := psampl.NewZipf(2.0, 1.0, 1000)
+	if err != nil {
+		t.Fatalf("could not build Zipf %s:", err)
+	}
+	for i := 0; i < nTestSampleInts; i++ {
+		n := z.Sample(rsrc)
+		if n > 1000 {
+			t.Fatalf("zipf sample %d out of range [0,1000]", n)
+		}
+	}
+}
+
+func TestZipfSourceRead(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	z, err := psampl.NewZipf(2.0, 1.0, 1000)
+	if err != nil {
+		t.Fatalf("could not build Zipf %s:", err)
+	}
+	zs := z.NewSource(rsrc)
+	p := make([]byte, 2*nTestSampleInts)
+	n, err := zs.Read(p)
+	if err != nil || n != len(p) {
+		t.Fatal("read failed")
+	}
+}
+
+func TestZipfInvalidParams(t *testing.T) {
+	if _, err := psampl.NewZipf(1.0, 1.0, 1000); err == nil {
+		t.Error("expected an error for s<=1")
+	}
+	if _, err := psampl.NewZipf(2.0, 0.5, 1000); err == nil {
+		t.Error("expected an error for v<1")
+	}
+}
+
+// TestZipfShiftedDistribution exercises v>1, where an earlier version of
+// the hx0minusHxm precomputation used exp((1-s)*ln(v)) instead of the
+// correct exp(-s*ln(v)); with v=1 (as used by the other Zipf tests)
+// ln(v)=0 makes both formulas numerically identical, silently hiding the
+// bug. This test checks empirical frequencies against the closed-form
+// pmf P(k) proportional to (v+k)^-s for several small k, which only
+// agree when the correct coefficient is used.
+func TestZipfShiftedDistribution(t *testing.T) {
+	rsrc := rand.New(rand.NewSource(1))
+	const s, v = 2.0, 3.0
+	const imax = 50
+	const n = 2000000
+
+	z, err := psampl.NewZipf(s, v, imax)
+	if err != nil {
+		t.Fatalf("could not build Zipf %s:", err)
+	}
+
+	counts := make([]int, imax+1)
+	for i := 0; i < n; i++ {
+		counts[z.Sample(rsrc)]++
+	}
+
+	const nCheck = 10
+	weights := make([]float64, imax+1)
+	sum := 0.0
+	for k := range weights {
+		weights[k] = math.Pow(v+float64(k), -s)
+		sum += weights[k]
+	}
+
+	for k := 0; k < nCheck; k++ {
+		want := weights[k] / sum
+		got := float64(counts[k]) / n
+		tol := math.Max(0.1*want, 0.002)
+		if math.Abs(got-want) > tol {
+			t.Errorf("k=%d: obtained prob %f and should be close to %f (tol %f)", k, got, want, tol)
+		}
+	}
+}